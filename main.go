@@ -1,23 +1,39 @@
 package main
 
 import (
-	"bytes"
 	"context"
-	_ "embed"
 	"encoding/json"
+	"flag"
 	"fmt"
-	"github.com/vektah/gqlparser/ast"
 	"log"
-	"net/http"
 	"os"
 	"strings"
-	"time"
-)
 
-//go:embed introspectionQuery.graphql
-var introspectionQuery string
+	"github.com/netixx/gqlfetch/pkg/gqlfetch"
+)
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "diff":
+			runDiff(os.Args[2:])
+			return
+		case "federation":
+			runFederation(os.Args[2:])
+			return
+		}
+	}
+	runFetch(os.Args[1:])
+}
+
+func runFetch(args []string) {
+	fs := flag.NewFlagSet("gqlfetch", flag.ExitOnError)
+	sdlPath := fs.String("sdl-path", "/schema.graphql", "path to try for the raw SDL fallback method")
+	methodsFlag := fs.String("methods", string(gqlfetch.MethodIntrospection), "comma-separated fetch methods to try in order: introspection, apq, sdl")
+	if err := fs.Parse(args); err != nil {
+		log.Fatal(err)
+	}
+
 	ctx := context.Background()
 	endpoint := os.Getenv("SERVER_ENDPOINT")
 	if strings.TrimSpace(endpoint) == "" {
@@ -26,295 +42,128 @@ func main() {
 
 	authorization := os.Getenv("AUTHORIZATION_HEADER")
 
-	buffer := new(bytes.Buffer)
-	err := json.NewEncoder(buffer).Encode(graphQLRequest{Query: introspectionQuery})
+	var methods []gqlfetch.Method
+	for _, name := range strings.Split(*methodsFlag, ",") {
+		methods = append(methods, gqlfetch.Method(strings.TrimSpace(name)))
+	}
+
+	schema, method, err := gqlfetch.FetchSchemaWithFallback(ctx, endpoint, *sdlPath, methods, gqlfetch.WithHeader("Authorization", authorization))
 	if err != nil {
 		log.Fatal(err)
 	}
+	log.Printf("fetched schema using method %q", method)
+	fmt.Println(gqlfetch.PrintASTSchema(schema))
+}
 
-	req, err := http.NewRequest(http.MethodPost, endpoint, buffer)
-	if err != nil {
+func runFederation(args []string) {
+	fs := flag.NewFlagSet("federation", flag.ExitOnError)
+	mode := fs.String("mode", "supergraph", "what to print: supergraph or subgraphs")
+	format := fs.String("format", "text", "diagnostics report format: text or json")
+	if err := fs.Parse(args); err != nil {
 		log.Fatal(err)
 	}
+	if fs.NArg() == 0 {
+		log.Fatal("usage: gqlfetch federation [--mode supergraph|subgraphs] <name>=<endpoint>...")
+	}
 
-	req.Header.Add("Authorization", authorization)
-	req.Header.Add("Content-Type", "application/json")
+	var subgraphs []gqlfetch.Subgraph
+	for _, arg := range fs.Args() {
+		name, endpoint, ok := strings.Cut(arg, "=")
+		if !ok {
+			log.Fatalf("invalid subgraph %q, expected name=endpoint", arg)
+		}
+		subgraphs = append(subgraphs, gqlfetch.Subgraph{Name: name, Endpoint: endpoint})
+	}
 
-	client := http.Client{Timeout: 2 * time.Minute}
-	res, err := client.Do(req.WithContext(ctx))
+	ctx := context.Background()
+	authorization := os.Getenv("AUTHORIZATION_HEADER")
+	schemas, err := gqlfetch.FetchSubgraphs(ctx, subgraphs, gqlfetch.WithHeader("Authorization", authorization))
 	if err != nil {
 		log.Fatal(err)
 	}
-	defer res.Body.Close()
 
-	var schemaResponse introspectionRes
-	err = json.NewDecoder(res.Body).Decode(&schemaResponse)
+	supergraph, diagnostics, err := gqlfetch.Compose(schemas)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	if len(schemaResponse.Errors) != 0 {
-		log.Fatal(schemaResponse.Errors)
+	switch *format {
+	case "json":
+		if err := json.NewEncoder(os.Stderr).Encode(diagnostics); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		for _, sg := range diagnostics.Subgraphs {
+			fmt.Fprintf(os.Stderr, "%s: %d entities (%s)\n", sg.Name, len(sg.Entities), strings.Join(sg.Entities, ", "))
+		}
+		for _, conflict := range diagnostics.Conflicts {
+			fmt.Fprintf(os.Stderr, "conflict: %s\n", conflict)
+		}
 	}
 
-	fmt.Println(printSchema(schemaResponse.Data.Schema))
-}
-
-type tabbedStringBuilder struct {
-	sb          *strings.Builder
-	IndentLevel int
-}
-
-func (sb *tabbedStringBuilder) WriteString(s string) {
-	if sb.IndentLevel != 0 {
-		sb.sb.WriteString(strings.Repeat("\t", sb.IndentLevel))
+	switch *mode {
+	case "subgraphs":
+		for _, sg := range schemas {
+			fmt.Printf("# %s\n%s\n", sg.Subgraph.Name, sg.SDL)
+		}
+	default:
+		fmt.Println(gqlfetch.PrintASTSchema(supergraph))
 	}
-	sb.sb.WriteString(s)
 }
 
-func (sb *tabbedStringBuilder) String() string {
-	return sb.sb.String()
-}
-
-func printSchema(schema GraphQLSchema) string {
-	sb := &tabbedStringBuilder{
-		sb: &strings.Builder{},
+func runDiff(args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	format := fs.String("format", "text", "output format: text or json")
+	if err := fs.Parse(args); err != nil {
+		log.Fatal(err)
 	}
 
-	printDirectives(sb, schema.Directives)
-	sb.WriteString("\n")
-	printTypes(sb, schema.Types)
-
-	return sb.String()
-}
-
-func printDirectives(sb *tabbedStringBuilder, directives []Directive) {
-	for _, directive := range directives {
-		printDescription(sb, directive.Description)
-		sb.WriteString(fmt.Sprintf("directive @%s", directive.Name))
-		if len(directive.Args) > 0 {
-			sb.WriteString("(\n")
-			sb.IndentLevel += 1
-			for _, arg := range directive.Args {
-				printDescription(sb, arg.Description)
-				sb.WriteString(fmt.Sprintf("%s: %s\n", arg.Name, arg.Type.String()))
-			}
-			sb.IndentLevel -= 1
-			sb.WriteString(")")
-		}
-
-		sb.WriteString(" on ")
-		for i, location := range directive.Locations {
-			sb.WriteString(string(location))
-			if i < len(directive.Locations)-1 {
-				sb.WriteString(" | ")
-			}
-		}
-		sb.WriteString("\n")
-		sb.WriteString("\n")
+	if fs.NArg() != 2 {
+		log.Fatal("usage: gqlfetch diff <old.graphql> <new-endpoint>")
 	}
-}
+	oldPath, newEndpoint := fs.Arg(0), fs.Arg(1)
 
-func printDescription(sb *tabbedStringBuilder, description string) {
-	if description != "" {
-		sb.WriteString(fmt.Sprintf(`"""%s"""`, description))
-		sb.WriteString("\n")
+	oldSDL, err := os.ReadFile(oldPath)
+	if err != nil {
+		log.Fatalf("reading %s: %v", oldPath, err)
 	}
-}
-
-func printTypes(sb *tabbedStringBuilder, types []Types) {
-	for _, typ := range types {
-		printDescription(sb, typ.Description)
-
-		switch typ.Kind {
-
-		case ast.Object:
-			sb.WriteString(fmt.Sprintf("type %s ", typ.Name))
-			if len(typ.Interfaces) > 0 {
-				sb.WriteString("implements ")
-				for i, intface := range typ.Interfaces {
-					sb.WriteString(intface.Name)
-					if i < len(typ.Interfaces)-1 {
-						sb.WriteString(" & ")
-					}
-				}
-			}
-			sb.WriteString("{\n")
-			sb.IndentLevel += 1
-			for _, field := range typ.Fields {
-				printDescription(sb, field.Description)
-				sb.WriteString(fmt.Sprintf("%s: %s\n", field.Name, field.Type.String()))
-			}
-			sb.IndentLevel -= 1
-			sb.WriteString("}")
-
-		case ast.Union:
-			sb.WriteString(fmt.Sprintf("union %s =", typ.Name))
-			var possible []*Type
-			if err := json.Unmarshal(typ.PossibleTypes, &possible); err != nil {
-				panic(err)
-			}
-			for i, typ := range possible {
-				sb.WriteString(typ.String())
-				if i < len(possible)-1 {
-					sb.WriteString(" | ")
-				}
-			}
-
-		case ast.Enum:
-			sb.WriteString(fmt.Sprintf("enum %s {\n", typ.Name))
-			var enumValues ast.EnumValueList
-			if err := json.Unmarshal(typ.EnumValues, &enumValues); err != nil {
-				panic(err)
-			}
-			sb.IndentLevel += 1
-			for _, value := range enumValues {
-				printDescription(sb, value.Description)
-				sb.WriteString(fmt.Sprintf("%s\n", value.Name))
-			}
-			sb.IndentLevel -= 1
-			sb.WriteString("}")
-
-		case ast.Scalar:
-			sb.WriteString(fmt.Sprintf("scalar %s", typ.Name))
-
-		case ast.InputObject:
-			sb.WriteString(fmt.Sprintf("input %s {\n", typ.Name))
-			sb.IndentLevel += 1
-			for _, field := range typ.Fields {
-				printDescription(sb, typ.Description)
-				sb.WriteString(fmt.Sprintf("%s: %s\n", field.Name, field.Type.String()))
-			}
-			sb.IndentLevel -= 1
-			sb.WriteString("}")
-
-		case ast.Interface:
-			sb.WriteString(fmt.Sprintf("interface %s {\n", typ.Name))
-			sb.IndentLevel += 1
-			for _, field := range typ.Fields {
-				printDescription(sb, typ.Description)
-				sb.WriteString(fmt.Sprintf("%s: %s\n", field.Name, field.Type.String()))
-			}
-			sb.IndentLevel -= 1
-			sb.WriteString("}")
-
-		default:
-			panic(fmt.Sprint("not handling", typ.Kind))
-		}
-		sb.WriteString("\n")
-		sb.WriteString("\n")
+	oldSchema, err := gqlfetch.LoadSchemaSDL(string(oldSDL))
+	if err != nil {
+		log.Fatalf("parsing %s: %v", oldPath, err)
 	}
-}
-
-type graphQLRequest struct {
-	Query     string                 `json:"query"`
-	Variables map[string]interface{} `json:"variables"`
-}
-
-type graphqlErrs []graphqlErr
-
-type graphqlErr struct {
-	Message string `json:"message"`
-}
-
-type introspectionRes struct {
-	Errors graphqlErrs `json:"errors"`
-	Data   struct {
-		Schema GraphQLSchema `json:"__schema"`
-	} `json:"data"`
-}
-
-type GraphQLSchema struct {
-	QueryType    ast.Definition `json:"queryType"`
-	MutationType ast.Definition `json:"mutationType"`
-	Types        []Types        `json:"types"`
-	Directives   []Directive    `json:"directives"`
-}
-
-type Types struct {
-	Kind        ast.DefinitionKind `json:"kind"`
-	Name        string             `json:"name"`
-	Description string             `json:"description"`
-	Fields      []struct {
-		Name              string        `json:"name"`
-		Description       string        `json:"description"`
-		Args              []interface{} `json:"args"`
-		Type              *Type         `json:"type"`
-		IsDeprecated      bool          `json:"isDeprecated"`
-		DeprecationReason interface{}   `json:"deprecationReason"`
-	} `json:"fields"`
-	InputFields   []InputField     `json:"inputFields"`
-	Interfaces    []ast.Definition `json:"interfaces"`
-	EnumValues    json.RawMessage  `json:"enumValues"`
-	PossibleTypes json.RawMessage  `json:"possibleTypes"`
-}
-
-type InputField struct {
-	Name         string      `json:"name"`
-	Description  string      `json:"description"`
-	Type         Type        `json:"type"`
-	DefaultValue interface{} `json:"defaultValue"`
-}
 
-type Directive struct {
-	Name        string                  `json:"name"`
-	Description string                  `json:"description"`
-	Locations   []ast.DirectiveLocation `json:"locations"`
-	Args        []struct {
-		Name         string      `json:"name"`
-		Description  string      `json:"description"`
-		Type         *Type       `json:"type"`
-		DefaultValue interface{} `json:"defaultValue"`
-	} `json:"args"`
-}
-
-type Type struct {
-	ast.Type
-}
-
-func (t *Type) UnmarshalJSON(data []byte) error {
-	var typ introspectedType
-	if err := json.Unmarshal(data, &typ); err != nil {
-		return err
+	ctx := context.Background()
+	authorization := os.Getenv("AUTHORIZATION_HEADER")
+	newIntrospected, err := gqlfetch.FetchSchema(ctx, newEndpoint, gqlfetch.WithHeader("Authorization", authorization))
+	if err != nil {
+		log.Fatalf("fetching %s: %v", newEndpoint, err)
+	}
+	newSchema, err := gqlfetch.ToAST(newIntrospected)
+	if err != nil {
+		log.Fatalf("parsing schema fetched from %s: %v", newEndpoint, err)
 	}
 
-	head := introspectionTypeToAstType(&typ)
-	t.NamedType = head.NamedType
-	t.Elem = head.Elem
-	t.NonNull = head.NonNull
-
-	return nil
-}
+	changes := gqlfetch.Diff(oldSchema, newSchema)
 
-func introspectionTypeToAstType(typ *introspectedType) *ast.Type {
-	var res ast.Type
-	if typ.OfType == nil {
-		res.NamedType = *typ.Name
-		return &res
+	switch *format {
+	case "json":
+		if err := json.NewEncoder(os.Stdout).Encode(changes); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		for _, change := range changes {
+			fmt.Println(change.String())
+		}
 	}
 
-	switch typ.Kind {
-	case NON_NULL:
-		res.NonNull = true
-		res.Elem = introspectionTypeToAstType(typ.OfType)
-		return &res
-	case LIST:
-		res.Elem = introspectionTypeToAstType(typ.OfType)
-		return &res
+	breaking := false
+	for _, change := range changes {
+		if change.Severity == gqlfetch.SeverityBreaking {
+			breaking = true
+			break
+		}
+	}
+	if breaking {
+		os.Exit(1)
 	}
-
-	return nil
-}
-
-type introspectedType struct {
-	Kind   TypeKind          `json:"kind"`
-	Name   *string           `json:"name"`
-	OfType *introspectedType `json:"ofType"`
 }
-
-type TypeKind string
-
-const (
-	NON_NULL TypeKind = "NON_NULL"
-	LIST     TypeKind = "LIST"
-)
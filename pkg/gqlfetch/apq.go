@@ -0,0 +1,67 @@
+package gqlfetch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// APQ returns a Middleware implementing Apollo's persisted-query protocol:
+// the query is first sent as a sha256 hash, and only resent in full if the
+// server reports PersistedQueryNotFound. It lets FetchSchema work against
+// APQ-only endpoints that reject arbitrary query bodies.
+func APQ() Middleware {
+	return func(next Transport) Transport {
+		return &apqTransport{next: next}
+	}
+}
+
+type apqTransport struct {
+	next Transport
+}
+
+func (t *apqTransport) Do(ctx context.Context, req graphQLRequest) ([]byte, error) {
+	hash := sha256.Sum256([]byte(req.Query))
+	hashHex := hex.EncodeToString(hash[:])
+
+	hashOnly := req
+	hashOnly.Query = ""
+	hashOnly.Extensions = persistedQueryExtensions(hashHex)
+
+	body, err := t.next.Do(ctx, hashOnly)
+	if err != nil {
+		return nil, err
+	}
+	if !isPersistedQueryNotFound(body) {
+		return body, nil
+	}
+
+	withQuery := req
+	withQuery.Extensions = persistedQueryExtensions(hashHex)
+	return t.next.Do(ctx, withQuery)
+}
+
+func persistedQueryExtensions(sha256Hash string) map[string]interface{} {
+	return map[string]interface{}{
+		"persistedQuery": map[string]interface{}{
+			"version":    1,
+			"sha256Hash": sha256Hash,
+		},
+	}
+}
+
+func isPersistedQueryNotFound(body []byte) bool {
+	var res struct {
+		Errors graphqlErrs `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return false
+	}
+	for _, gqlErr := range res.Errors {
+		if gqlErr.Message == "PersistedQueryNotFound" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,436 @@
+package gqlfetch
+
+import (
+	"fmt"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Severity classifies how disruptive a Change is to existing clients.
+type Severity string
+
+const (
+	// SeverityBreaking changes will break existing queries or clients.
+	SeverityBreaking Severity = "BREAKING"
+	// SeverityDangerous changes are unlikely to break existing clients but
+	// may change behaviour in ways worth a human reviewing.
+	SeverityDangerous Severity = "DANGEROUS"
+	// SeveritySafe changes are backwards compatible.
+	SeveritySafe Severity = "SAFE"
+)
+
+// Change describes a single difference between two schemas.
+type Change struct {
+	Severity Severity `json:"severity"`
+	Path     string   `json:"path"`
+	Message  string   `json:"message"`
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("[%s] %s: %s", c.Severity, c.Path, c.Message)
+}
+
+// ToAST parses a fetched Schema's rendered SDL back into a *ast.Schema, so
+// it can be compared with Diff or fed to other gqlparser-based tooling.
+func ToAST(schema *Schema) (*ast.Schema, error) {
+	return LoadSchemaSDL(PrintSchema(schema))
+}
+
+// LoadSchemaSDL parses raw SDL, e.g. read from disk, into a *ast.Schema so
+// it can be compared with Diff.
+func LoadSchemaSDL(sdl string) (*ast.Schema, error) {
+	schema, gqlErr := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: sdl})
+	if gqlErr != nil {
+		return nil, gqlErr
+	}
+	return schema, nil
+}
+
+// Diff compares oldSchema against newSchema and reports the differences,
+// classified by how likely they are to break existing clients.
+func Diff(oldSchema, newSchema *ast.Schema) []Change {
+	var changes []Change
+
+	for name, oldType := range oldSchema.Types {
+		newType, ok := newSchema.Types[name]
+		if !ok {
+			if !isBuiltinType(name) {
+				changes = append(changes, Change{
+					Severity: SeverityBreaking,
+					Path:     name,
+					Message:  "type was removed",
+				})
+			}
+			continue
+		}
+		changes = append(changes, diffType(oldType, newType)...)
+	}
+
+	for name := range newSchema.Types {
+		if _, ok := oldSchema.Types[name]; !ok && !isBuiltinType(name) {
+			changes = append(changes, Change{
+				Severity: SeveritySafe,
+				Path:     name,
+				Message:  "type was added",
+			})
+		}
+	}
+
+	for name, oldDirective := range oldSchema.Directives {
+		newDirective, ok := newSchema.Directives[name]
+		if !ok {
+			changes = append(changes, Change{
+				Severity: SeverityBreaking,
+				Path:     "@" + name,
+				Message:  "directive was removed",
+			})
+			continue
+		}
+		changes = append(changes, diffDirective(oldDirective, newDirective)...)
+	}
+
+	for name := range newSchema.Directives {
+		if _, ok := oldSchema.Directives[name]; !ok {
+			changes = append(changes, Change{
+				Severity: SeveritySafe,
+				Path:     "@" + name,
+				Message:  "directive was added",
+			})
+		}
+	}
+
+	return changes
+}
+
+func diffType(oldType, newType *ast.Definition) []Change {
+	path := oldType.Name
+	var changes []Change
+
+	if oldType.Kind != newType.Kind {
+		changes = append(changes, Change{
+			Severity: SeverityBreaking,
+			Path:     path,
+			Message:  fmt.Sprintf("kind changed from %s to %s", oldType.Kind, newType.Kind),
+		})
+		return changes
+	}
+
+	switch oldType.Kind {
+	case ast.InputObject:
+		changes = append(changes, diffInputFields(path, oldType.Fields, newType.Fields)...)
+	case ast.Enum:
+		changes = append(changes, diffEnumValues(path, oldType.EnumValues, newType.EnumValues)...)
+	case ast.Object, ast.Interface:
+		changes = append(changes, diffFields(path, oldType.Fields, newType.Fields)...)
+		changes = append(changes, diffInterfaces(path, oldType.Interfaces, newType.Interfaces)...)
+	case ast.Union:
+		changes = append(changes, diffUnionMembers(path, oldType.Types, newType.Types)...)
+	}
+
+	return changes
+}
+
+func diffFields(path string, oldFields, newFields ast.FieldList) []Change {
+	var changes []Change
+
+	for _, oldField := range oldFields {
+		fieldPath := fmt.Sprintf("%s.%s", path, oldField.Name)
+		newField := newFields.ForName(oldField.Name)
+		if newField == nil {
+			changes = append(changes, Change{
+				Severity: SeverityBreaking,
+				Path:     fieldPath,
+				Message:  "field was removed",
+			})
+			continue
+		}
+		if oldField.Type.String() != newField.Type.String() {
+			changes = append(changes, Change{
+				Severity: SeverityBreaking,
+				Path:     fieldPath,
+				Message:  fmt.Sprintf("type changed from %s to %s", oldField.Type.String(), newField.Type.String()),
+			})
+		}
+		changes = append(changes, diffArgs(fieldPath, oldField.Arguments, newField.Arguments)...)
+	}
+
+	for _, newField := range newFields {
+		if oldFields.ForName(newField.Name) == nil {
+			changes = append(changes, Change{
+				Severity: SeveritySafe,
+				Path:     fmt.Sprintf("%s.%s", path, newField.Name),
+				Message:  "field was added",
+			})
+		}
+	}
+
+	return changes
+}
+
+func diffInputFields(path string, oldFields, newFields ast.FieldList) []Change {
+	var changes []Change
+
+	for _, oldField := range oldFields {
+		fieldPath := fmt.Sprintf("%s.%s", path, oldField.Name)
+		newField := newFields.ForName(oldField.Name)
+		if newField == nil {
+			changes = append(changes, Change{
+				Severity: SeverityBreaking,
+				Path:     fieldPath,
+				Message:  "input field was removed",
+			})
+			continue
+		}
+		if oldField.Type.String() != newField.Type.String() {
+			changes = append(changes, Change{
+				Severity: SeverityBreaking,
+				Path:     fieldPath,
+				Message:  fmt.Sprintf("type changed from %s to %s", oldField.Type.String(), newField.Type.String()),
+			})
+		}
+		if defaultValueString(oldField.DefaultValue) != defaultValueString(newField.DefaultValue) {
+			changes = append(changes, Change{
+				Severity: SeverityDangerous,
+				Path:     fieldPath,
+				Message:  fmt.Sprintf("default value changed from %s to %s", defaultValueString(oldField.DefaultValue), defaultValueString(newField.DefaultValue)),
+			})
+		}
+	}
+
+	for _, newField := range newFields {
+		if oldFields.ForName(newField.Name) != nil {
+			continue
+		}
+		if newField.Type.NonNull && newField.DefaultValue == nil {
+			changes = append(changes, Change{
+				Severity: SeverityBreaking,
+				Path:     fmt.Sprintf("%s.%s", path, newField.Name),
+				Message:  "required input field was added",
+			})
+			continue
+		}
+		changes = append(changes, Change{
+			Severity: SeveritySafe,
+			Path:     fmt.Sprintf("%s.%s", path, newField.Name),
+			Message:  "optional input field was added",
+		})
+	}
+
+	return changes
+}
+
+func diffArgs(path string, oldArgs, newArgs ast.ArgumentDefinitionList) []Change {
+	var changes []Change
+
+	for _, oldArg := range oldArgs {
+		argPath := fmt.Sprintf("%s(%s:)", path, oldArg.Name)
+		newArg := newArgs.ForName(oldArg.Name)
+		if newArg == nil {
+			changes = append(changes, Change{
+				Severity: SeverityBreaking,
+				Path:     argPath,
+				Message:  "argument was removed",
+			})
+			continue
+		}
+		if oldArg.Type.String() != newArg.Type.String() {
+			changes = append(changes, Change{
+				Severity: SeverityBreaking,
+				Path:     argPath,
+				Message:  fmt.Sprintf("type changed from %s to %s", oldArg.Type.String(), newArg.Type.String()),
+			})
+		}
+		if defaultValueString(oldArg.DefaultValue) != defaultValueString(newArg.DefaultValue) {
+			changes = append(changes, Change{
+				Severity: SeverityDangerous,
+				Path:     argPath,
+				Message:  fmt.Sprintf("default value changed from %s to %s", defaultValueString(oldArg.DefaultValue), defaultValueString(newArg.DefaultValue)),
+			})
+		}
+	}
+
+	for _, newArg := range newArgs {
+		if oldArgs.ForName(newArg.Name) != nil {
+			continue
+		}
+		if newArg.Type.NonNull && newArg.DefaultValue == nil {
+			changes = append(changes, Change{
+				Severity: SeverityBreaking,
+				Path:     fmt.Sprintf("%s(%s:)", path, newArg.Name),
+				Message:  "required argument was added",
+			})
+			continue
+		}
+		changes = append(changes, Change{
+			Severity: SeveritySafe,
+			Path:     fmt.Sprintf("%s(%s:)", path, newArg.Name),
+			Message:  "optional argument was added",
+		})
+	}
+
+	return changes
+}
+
+func diffEnumValues(path string, oldValues, newValues ast.EnumValueList) []Change {
+	var changes []Change
+
+	for _, oldValue := range oldValues {
+		if newValues.ForName(oldValue.Name) == nil {
+			changes = append(changes, Change{
+				Severity: SeverityBreaking,
+				Path:     fmt.Sprintf("%s.%s", path, oldValue.Name),
+				Message:  "enum value was removed",
+			})
+		}
+	}
+
+	for _, newValue := range newValues {
+		if oldValues.ForName(newValue.Name) == nil {
+			changes = append(changes, Change{
+				Severity: SeverityDangerous,
+				Path:     fmt.Sprintf("%s.%s", path, newValue.Name),
+				Message:  "enum value was added",
+			})
+		}
+	}
+
+	return changes
+}
+
+func diffInterfaces(path string, oldInterfaces, newInterfaces []string) []Change {
+	var changes []Change
+
+	newSet := make(map[string]bool, len(newInterfaces))
+	for _, name := range newInterfaces {
+		newSet[name] = true
+	}
+	oldSet := make(map[string]bool, len(oldInterfaces))
+	for _, name := range oldInterfaces {
+		oldSet[name] = true
+	}
+
+	for _, name := range oldInterfaces {
+		if !newSet[name] {
+			changes = append(changes, Change{
+				Severity: SeverityBreaking,
+				Path:     path,
+				Message:  fmt.Sprintf("no longer implements %s", name),
+			})
+		}
+	}
+	for _, name := range newInterfaces {
+		if !oldSet[name] {
+			changes = append(changes, Change{
+				Severity: SeveritySafe,
+				Path:     path,
+				Message:  fmt.Sprintf("now implements %s", name),
+			})
+		}
+	}
+
+	return changes
+}
+
+func diffUnionMembers(path string, oldMembers, newMembers []string) []Change {
+	var changes []Change
+
+	newSet := make(map[string]bool, len(newMembers))
+	for _, name := range newMembers {
+		newSet[name] = true
+	}
+	oldSet := make(map[string]bool, len(oldMembers))
+	for _, name := range oldMembers {
+		oldSet[name] = true
+	}
+
+	for _, name := range oldMembers {
+		if !newSet[name] {
+			changes = append(changes, Change{
+				Severity: SeverityBreaking,
+				Path:     path,
+				Message:  fmt.Sprintf("member %s was removed", name),
+			})
+		}
+	}
+	for _, name := range newMembers {
+		if !oldSet[name] {
+			changes = append(changes, Change{
+				Severity: SeveritySafe,
+				Path:     path,
+				Message:  fmt.Sprintf("member %s was added", name),
+			})
+		}
+	}
+
+	return changes
+}
+
+func diffDirective(oldDirective, newDirective *ast.DirectiveDefinition) []Change {
+	path := "@" + oldDirective.Name
+	var changes []Change
+
+	oldLocations := make(map[ast.DirectiveLocation]bool, len(oldDirective.Locations))
+	for _, loc := range oldDirective.Locations {
+		oldLocations[loc] = true
+	}
+	newLocations := make(map[ast.DirectiveLocation]bool, len(newDirective.Locations))
+	for _, loc := range newDirective.Locations {
+		newLocations[loc] = true
+	}
+
+	for loc := range oldLocations {
+		if !newLocations[loc] {
+			changes = append(changes, Change{
+				Severity: SeverityBreaking,
+				Path:     path,
+				Message:  fmt.Sprintf("location %s was removed", loc),
+			})
+		}
+	}
+	for loc := range newLocations {
+		if !oldLocations[loc] {
+			changes = append(changes, Change{
+				Severity: SeveritySafe,
+				Path:     path,
+				Message:  fmt.Sprintf("location %s was added", loc),
+			})
+		}
+	}
+
+	if oldDirective.IsRepeatable && !newDirective.IsRepeatable {
+		changes = append(changes, Change{
+			Severity: SeverityBreaking,
+			Path:     path,
+			Message:  "is no longer repeatable",
+		})
+	}
+	if !oldDirective.IsRepeatable && newDirective.IsRepeatable {
+		changes = append(changes, Change{
+			Severity: SeverityDangerous,
+			Path:     path,
+			Message:  "is now repeatable",
+		})
+	}
+
+	changes = append(changes, diffArgs(path, oldDirective.Arguments, newDirective.Arguments)...)
+
+	return changes
+}
+
+func defaultValueString(v *ast.Value) string {
+	if v == nil {
+		return "<none>"
+	}
+	return v.String()
+}
+
+func isBuiltinType(name string) bool {
+	switch name {
+	case "String", "Int", "Float", "Boolean", "ID",
+		"__Schema", "__Type", "__TypeKind", "__Field", "__InputValue",
+		"__EnumValue", "__Directive", "__DirectiveLocation":
+		return true
+	}
+	return len(name) > 1 && name[0:2] == "__"
+}
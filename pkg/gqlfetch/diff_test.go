@@ -0,0 +1,95 @@
+package gqlfetch
+
+import "testing"
+
+func TestLoadSchemaSDLSuccessReturnsNilError(t *testing.T) {
+	schema, err := LoadSchemaSDL(`
+		type Query {
+			hello: String
+		}
+	`)
+	if err != nil {
+		t.Fatalf("expected nil error for valid SDL, got: %v", err)
+	}
+	if schema == nil {
+		t.Fatal("expected a parsed schema")
+	}
+}
+
+func TestLoadSchemaSDLInvalidReturnsError(t *testing.T) {
+	_, err := LoadSchemaSDL(`type Query { hello: }`)
+	if err == nil {
+		t.Fatal("expected an error for invalid SDL")
+	}
+}
+
+func TestDiffUnionMembers(t *testing.T) {
+	oldSchema, err := LoadSchemaSDL(`
+		type Query { result: Result }
+		type A { a: String }
+		type B { b: String }
+		union Result = A | B
+	`)
+	if err != nil {
+		t.Fatalf("parsing old schema: %v", err)
+	}
+
+	newSchema, err := LoadSchemaSDL(`
+		type Query { result: Result }
+		type A { a: String }
+		type B { b: String }
+		type C { c: String }
+		union Result = A | C
+	`)
+	if err != nil {
+		t.Fatalf("parsing new schema: %v", err)
+	}
+
+	changes := Diff(oldSchema, newSchema)
+
+	var sawRemoved, sawAdded bool
+	for _, c := range changes {
+		if c.Path == "Result" && c.Severity == SeverityBreaking && c.Message == "member B was removed" {
+			sawRemoved = true
+		}
+		if c.Path == "Result" && c.Severity == SeveritySafe && c.Message == "member C was added" {
+			sawAdded = true
+		}
+	}
+	if !sawRemoved {
+		t.Errorf("expected a breaking change for removed union member B, got: %v", changes)
+	}
+	if !sawAdded {
+		t.Errorf("expected a safe change for added union member C, got: %v", changes)
+	}
+}
+
+func TestDiffDirectiveRepeatable(t *testing.T) {
+	oldSchema, err := LoadSchemaSDL(`
+		directive @cached on FIELD_DEFINITION
+		type Query { hello: String }
+	`)
+	if err != nil {
+		t.Fatalf("parsing old schema: %v", err)
+	}
+
+	newSchema, err := LoadSchemaSDL(`
+		directive @cached repeatable on FIELD_DEFINITION
+		type Query { hello: String }
+	`)
+	if err != nil {
+		t.Fatalf("parsing new schema: %v", err)
+	}
+
+	changes := Diff(oldSchema, newSchema)
+
+	var sawRepeatable bool
+	for _, c := range changes {
+		if c.Path == "@cached" && c.Severity == SeverityDangerous && c.Message == "is now repeatable" {
+			sawRepeatable = true
+		}
+	}
+	if !sawRepeatable {
+		t.Errorf("expected a dangerous change for @cached becoming repeatable, got: %v", changes)
+	}
+}
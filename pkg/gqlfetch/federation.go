@@ -0,0 +1,278 @@
+package gqlfetch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Subgraph identifies a single service in a federated graph.
+type Subgraph struct {
+	Name     string
+	Endpoint string
+}
+
+// SubgraphSchema is a Subgraph together with the SDL it reported via
+// `_service { sdl }` and the parsed result.
+type SubgraphSchema struct {
+	Subgraph Subgraph
+	SDL      string
+	Schema   *ast.Schema
+}
+
+// CompositionDiagnostics reports what ComposeSubgraphs found while merging
+// subgraph schemas: the entities (types carrying @key) owned by each
+// subgraph, and any conflicts that prevented a clean merge.
+type CompositionDiagnostics struct {
+	Subgraphs []SubgraphDiagnostic `json:"subgraphs"`
+	Conflicts []string             `json:"conflicts"`
+}
+
+// SubgraphDiagnostic summarizes a single subgraph's contribution to the
+// supergraph.
+type SubgraphDiagnostic struct {
+	Name     string   `json:"name"`
+	Entities []string `json:"entities"`
+}
+
+const federationServiceQuery = `query GqlfetchFederationService { _service { sdl } }`
+
+type federationServiceRes struct {
+	Errors graphqlErrs `json:"errors"`
+	Data   struct {
+		Service struct {
+			SDL string `json:"sdl"`
+		} `json:"_service"`
+	} `json:"data"`
+}
+
+// FetchSubgraphSDL issues the Apollo Federation `_service { sdl }` query
+// against a subgraph endpoint and returns its raw SDL.
+func FetchSubgraphSDL(ctx context.Context, endpoint string, opts ...Option) (string, error) {
+	cfg := resolveConfig(opts...)
+
+	transport := cfg.transport
+	if transport == nil {
+		transport = &HTTPTransport{
+			Endpoint:   endpoint,
+			Client:     cfg.clientWithTimeout(),
+			Headers:    cfg.headers,
+			HTTPMethod: cfg.httpMethod,
+		}
+	}
+	for i := len(cfg.middlewares) - 1; i >= 0; i-- {
+		transport = cfg.middlewares[i](transport)
+	}
+
+	body, err := transport.Do(ctx, graphQLRequest{Query: federationServiceQuery})
+	if err != nil {
+		return "", fmt.Errorf("fetching _service sdl: %w", err)
+	}
+
+	var res federationServiceRes
+	if err := json.Unmarshal(body, &res); err != nil {
+		return "", fmt.Errorf("decoding _service response: %w", err)
+	}
+	if len(res.Errors) != 0 {
+		return "", fmt.Errorf("_service errors: %v", res.Errors)
+	}
+
+	return res.Data.Service.SDL, nil
+}
+
+// FetchSubgraphs fetches and parses the SDL for every given Subgraph.
+func FetchSubgraphs(ctx context.Context, subgraphs []Subgraph, opts ...Option) ([]SubgraphSchema, error) {
+	schemas := make([]SubgraphSchema, 0, len(subgraphs))
+	for _, subgraph := range subgraphs {
+		sdl, err := FetchSubgraphSDL(ctx, subgraph.Endpoint, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("subgraph %s: %w", subgraph.Name, err)
+		}
+		schema, err := LoadSchemaSDL(sdl)
+		if err != nil {
+			return nil, fmt.Errorf("subgraph %s: parsing sdl: %w", subgraph.Name, err)
+		}
+		schemas = append(schemas, SubgraphSchema{Subgraph: subgraph, SDL: sdl, Schema: schema})
+	}
+	return schemas, nil
+}
+
+// ComposeSubgraphs fetches each subgraph's SDL and merges them into a
+// single supergraph, detecting type ownership conflicts along the way.
+// Types carrying @key are merged as entities and @external fields are left
+// to the subgraph that owns them. The field sets of @requires and @provides
+// are checked against the merged type's fields; @key field sets are not
+// checked.
+func ComposeSubgraphs(ctx context.Context, subgraphs []Subgraph, opts ...Option) (*ast.Schema, *CompositionDiagnostics, error) {
+	schemas, err := FetchSubgraphs(ctx, subgraphs, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return Compose(schemas)
+}
+
+// Compose merges already-fetched subgraph schemas into a supergraph. It is
+// split out from ComposeSubgraphs so tests and offline tooling can compose
+// schemas without a network round trip.
+func Compose(schemas []SubgraphSchema) (*ast.Schema, *CompositionDiagnostics, error) {
+	diagnostics := &CompositionDiagnostics{}
+
+	entityOwners := map[string][]string{} // type name -> subgraph names that key it
+	for _, sg := range schemas {
+		var entities []string
+		for name, typ := range sg.Schema.Types {
+			if isBuiltinType(name) {
+				continue
+			}
+			if typ.Directives.ForName("key") != nil {
+				entities = append(entities, name)
+				entityOwners[name] = append(entityOwners[name], sg.Subgraph.Name)
+			}
+		}
+		sort.Strings(entities)
+		diagnostics.Subgraphs = append(diagnostics.Subgraphs, SubgraphDiagnostic{Name: sg.Subgraph.Name, Entities: entities})
+	}
+
+	merged := map[string]*ast.Definition{}
+	firstDefinedIn := map[string]string{} // type name -> subgraph that first defined it
+	for _, sg := range schemas {
+		for name, typ := range sg.Schema.Types {
+			if isBuiltinType(name) {
+				continue
+			}
+			existing, ok := merged[name]
+			if !ok {
+				merged[name] = cloneDefinitionOwnedFields(typ)
+				firstDefinedIn[name] = sg.Subgraph.Name
+				continue
+			}
+
+			isEntity := len(entityOwners[name]) > 0
+			if isEntity {
+				if diff := entityFieldDiff(existing, typ); diff != "" {
+					diagnostics.Conflicts = append(diagnostics.Conflicts, fmt.Sprintf("%s: %s (defined in %s and %s)", name, diff, firstDefinedIn[name], sg.Subgraph.Name))
+					continue
+				}
+				mergeOwnedFields(existing, typ)
+				continue
+			}
+
+			if diff := valueTypeDiff(existing, typ); diff != "" {
+				diagnostics.Conflicts = append(diagnostics.Conflicts, fmt.Sprintf("%s: %s (defined in %s and %s)", name, diff, firstDefinedIn[name], sg.Subgraph.Name))
+				continue
+			}
+			mergeOwnedFields(existing, typ) // shared fields agree: still fold in any root-field-style additions
+		}
+	}
+
+	for name, typ := range merged {
+		for _, field := range typ.Fields {
+			if requires := field.Directives.ForName("requires"); requires != nil {
+				if err := validateFieldSet(typ, requires, "requires"); err != nil {
+					diagnostics.Conflicts = append(diagnostics.Conflicts, fmt.Sprintf("%s.%s: %v", name, field.Name, err))
+				}
+			}
+			if provides := field.Directives.ForName("provides"); provides != nil {
+				if err := validateFieldSet(typ, provides, "provides"); err != nil {
+					diagnostics.Conflicts = append(diagnostics.Conflicts, fmt.Sprintf("%s.%s: %v", name, field.Name, err))
+				}
+			}
+		}
+	}
+
+	supergraph := &ast.Schema{Types: merged, Directives: map[string]*ast.DirectiveDefinition{}}
+	if query, ok := merged["Query"]; ok {
+		supergraph.Query = query
+	}
+	if mutation, ok := merged["Mutation"]; ok {
+		supergraph.Mutation = mutation
+	}
+
+	return supergraph, diagnostics, nil
+}
+
+// cloneDefinitionOwnedFields copies typ, dropping fields marked @external
+// since those belong to whichever subgraph actually resolves them.
+func cloneDefinitionOwnedFields(typ *ast.Definition) *ast.Definition {
+	clone := *typ
+	clone.Fields = nil
+	for _, field := range typ.Fields {
+		if field.Directives.ForName("external") != nil {
+			continue
+		}
+		clone.Fields = append(clone.Fields, field)
+	}
+	return &clone
+}
+
+// mergeOwnedFields adds fields from typ that existing doesn't already
+// have, skipping @external fields (they are resolved by their owning
+// subgraph, not redeclared).
+func mergeOwnedFields(existing, typ *ast.Definition) {
+	for _, field := range typ.Fields {
+		if field.Directives.ForName("external") != nil {
+			continue
+		}
+		if existing.Fields.ForName(field.Name) == nil {
+			existing.Fields = append(existing.Fields, field)
+		}
+	}
+}
+
+// valueTypeDiff reports a human-readable difference between two
+// non-entity definitions that share a name, or "" if they are compatible.
+func valueTypeDiff(a, b *ast.Definition) string {
+	if a.Kind != b.Kind {
+		return fmt.Sprintf("kind mismatch (%s vs %s)", a.Kind, b.Kind)
+	}
+	for _, bf := range b.Fields {
+		af := a.Fields.ForName(bf.Name)
+		if af == nil {
+			continue // additional owned field, folded in by the caller
+		}
+		if af.Type.String() != bf.Type.String() {
+			return fmt.Sprintf("field %s type mismatch (%s vs %s)", bf.Name, af.Type.String(), bf.Type.String())
+		}
+	}
+	return ""
+}
+
+// entityFieldDiff reports a human-readable difference between two entity
+// definitions that share a name, or "" if their owned fields agree. Unlike
+// valueTypeDiff, it skips @external fields: those merely reference a field
+// owned by another subgraph and aren't a second, conflicting declaration of it.
+func entityFieldDiff(a, b *ast.Definition) string {
+	for _, bf := range b.Fields {
+		if bf.Directives.ForName("external") != nil {
+			continue
+		}
+		af := a.Fields.ForName(bf.Name)
+		if af == nil {
+			continue // additional owned field, folded in by the caller
+		}
+		if af.Type.String() != bf.Type.String() {
+			return fmt.Sprintf("field %s type mismatch (%s vs %s)", bf.Name, af.Type.String(), bf.Type.String())
+		}
+	}
+	return ""
+}
+
+// validateFieldSet does a shallow check that a @requires/@provides
+// selection set names fields that exist on typ.
+func validateFieldSet(typ *ast.Definition, directive *ast.Directive, name string) error {
+	fieldsArg := directive.Arguments.ForName("fields")
+	if fieldsArg == nil || fieldsArg.Value == nil {
+		return fmt.Errorf("@%s is missing a fields argument", name)
+	}
+	selection := strings.Trim(fieldsArg.Value.Raw, `"`)
+	for _, fieldName := range strings.Fields(selection) {
+		if typ.Fields.ForName(fieldName) == nil {
+			return fmt.Errorf("@%s references unknown field %q", name, fieldName)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package gqlfetch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func subgraphServer(t *testing.T, sdl string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"data":{"_service":{"sdl":%q}}}`, sdl)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestComposeSubgraphs(t *testing.T) {
+	users := subgraphServer(t, `
+		directive @key(fields: String!) on OBJECT
+
+		type User @key(fields: "id") {
+			id: ID!
+			name: String!
+		}
+		type Query {
+			me: User
+		}
+	`)
+	reviews := subgraphServer(t, `
+		directive @key(fields: String!) on OBJECT
+
+		type User @key(fields: "id") {
+			id: ID!
+			reviews: [String!]!
+		}
+		type Query {
+			reviewCount: Int!
+		}
+	`)
+
+	supergraph, diagnostics, err := ComposeSubgraphs(context.Background(), []Subgraph{
+		{Name: "users", Endpoint: users.URL},
+		{Name: "reviews", Endpoint: reviews.URL},
+	})
+	if err != nil {
+		t.Fatalf("ComposeSubgraphs: %v", err)
+	}
+	if len(diagnostics.Conflicts) != 0 {
+		t.Fatalf("expected no conflicts, got: %v", diagnostics.Conflicts)
+	}
+
+	user := supergraph.Types["User"]
+	if user == nil {
+		t.Fatal("expected User in supergraph")
+	}
+	if user.Fields.ForName("name") == nil || user.Fields.ForName("reviews") == nil {
+		t.Fatalf("expected User to merge fields from both subgraphs, got: %#v", user.Fields)
+	}
+
+	if supergraph.Query == nil || supergraph.Query.Fields.ForName("me") == nil || supergraph.Query.Fields.ForName("reviewCount") == nil {
+		t.Fatalf("expected Query to merge root fields from both subgraphs, got: %#v", supergraph.Query)
+	}
+}
+
+func TestComposeReportsValueTypeConflict(t *testing.T) {
+	a, err := LoadSchemaSDL(`
+		type Money {
+			amount: Int!
+		}
+		type Query { price: Money }
+	`)
+	if err != nil {
+		t.Fatalf("parsing subgraph a: %v", err)
+	}
+	b, err := LoadSchemaSDL(`
+		type Money {
+			amount: String!
+		}
+		type Query { price: Money }
+	`)
+	if err != nil {
+		t.Fatalf("parsing subgraph b: %v", err)
+	}
+
+	_, diagnostics, err := Compose([]SubgraphSchema{
+		{Subgraph: Subgraph{Name: "a"}, Schema: a},
+		{Subgraph: Subgraph{Name: "b"}, Schema: b},
+	})
+	if err != nil {
+		t.Fatalf("Compose: %v", err)
+	}
+	if len(diagnostics.Conflicts) == 0 {
+		t.Fatal("expected a conflict for Money.amount type mismatch")
+	}
+}
+
+func TestComposeReportsEntityFieldTypeConflict(t *testing.T) {
+	a, err := LoadSchemaSDL(`
+		directive @key(fields: String!) on OBJECT
+
+		type User @key(fields: "id") {
+			id: ID!
+			age: Int!
+		}
+		type Query { me: User }
+	`)
+	if err != nil {
+		t.Fatalf("parsing subgraph a: %v", err)
+	}
+	b, err := LoadSchemaSDL(`
+		directive @key(fields: String!) on OBJECT
+
+		type User @key(fields: "id") {
+			id: ID!
+			age: String!
+		}
+		type Query { me: User }
+	`)
+	if err != nil {
+		t.Fatalf("parsing subgraph b: %v", err)
+	}
+
+	_, diagnostics, err := Compose([]SubgraphSchema{
+		{Subgraph: Subgraph{Name: "a"}, Schema: a},
+		{Subgraph: Subgraph{Name: "b"}, Schema: b},
+	})
+	if err != nil {
+		t.Fatalf("Compose: %v", err)
+	}
+	if len(diagnostics.Conflicts) == 0 {
+		t.Fatal("expected a conflict for User.age type mismatch")
+	}
+}
@@ -0,0 +1,155 @@
+package gqlfetch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// FuzzPrintTypes builds Object, Union and InputObject schemas out of the
+// fuzzer's inputs, serves them from an in-memory introspection endpoint,
+// and checks that fetching and printing that schema always round-trips
+// through gqlparser to an equivalent AST. Serving the schema over HTTP
+// (rather than calling PrintSchema on the constructed value directly)
+// exercises FetchSchema and HTTPTransport too, not just the printer;
+// gqlgen itself isn't used because it requires generated resolvers per
+// schema, which the fuzzer's randomly shaped schemas can't provide. It
+// targets the printer bugs that only show up on schemas the other tests
+// don't happen to construct: missing separators, wrong field source,
+// wrong description.
+func FuzzPrintTypes(f *testing.F) {
+	f.Add("Widget", "name", "id", 2)
+	f.Add("A", "f", "g", 0)
+	f.Add("Ω1_x", "_1", "z9", 5)
+
+	f.Fuzz(func(t *testing.T, typeName, fieldA, fieldB string, memberCount int) {
+		typeName = sanitizeName(typeName, "T")
+		fieldA = sanitizeName(fieldA, "fieldA")
+		fieldB = sanitizeName(fieldB, "fieldB")
+		if fieldA == fieldB {
+			fieldB += "B"
+		}
+		if memberCount < 0 {
+			memberCount = -memberCount
+		}
+		memberCount = memberCount%4 + 1
+
+		wireNamedType := func(name string) map[string]interface{} {
+			return map[string]interface{}{"kind": "SCALAR", "name": name, "ofType": nil}
+		}
+
+		object := map[string]interface{}{
+			"kind": ast.Object,
+			"name": typeName,
+			"fields": []interface{}{
+				map[string]interface{}{"name": fieldA, "description": "field a", "args": []interface{}{}, "type": wireNamedType("String")},
+				map[string]interface{}{"name": fieldB, "description": "field b", "args": []interface{}{}, "type": wireNamedType("Int")},
+			},
+		}
+
+		input := map[string]interface{}{
+			"kind": ast.InputObject,
+			"name": typeName + "Input",
+			"inputFields": []interface{}{
+				map[string]interface{}{"name": fieldA, "description": "input field a", "type": wireNamedType("String")},
+			},
+		}
+
+		possibleTypes := make([]interface{}, memberCount)
+		for i := range possibleTypes {
+			possibleTypes[i] = map[string]interface{}{"name": fmt.Sprintf("%sMember%d", typeName, i)}
+		}
+		possibleTypesJSON, err := json.Marshal(possibleTypes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		union := map[string]interface{}{
+			"kind":          ast.Union,
+			"name":          typeName + "Union",
+			"possibleTypes": json.RawMessage(possibleTypesJSON),
+		}
+
+		members := make([]interface{}, memberCount)
+		for i := range members {
+			members[i] = map[string]interface{}{
+				"kind": ast.Object,
+				"name": fmt.Sprintf("%sMember%d", typeName, i),
+				"fields": []interface{}{
+					map[string]interface{}{"name": "id", "description": "", "args": []interface{}{}, "type": wireNamedType("ID")},
+				},
+			}
+		}
+
+		types := append([]interface{}{object, input, union}, members...)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{
+					"__schema": map[string]interface{}{
+						"queryType":    map[string]interface{}{},
+						"mutationType": map[string]interface{}{},
+						"types":        types,
+						"directives":   []interface{}{},
+					},
+				},
+			})
+		}))
+		defer server.Close()
+
+		fetched, err := FetchSchema(context.Background(), server.URL)
+		if err != nil {
+			t.Fatalf("FetchSchema: %v", err)
+		}
+
+		sdl := PrintSchema(fetched)
+		parsed, err := gqlparser.LoadSchema(&ast.Source{Name: "fuzz.graphql", Input: sdl})
+		if err != nil {
+			t.Fatalf("printed schema does not re-parse: %v\n%s", err, sdl)
+		}
+
+		objType := parsed.Types[typeName]
+		if objType == nil || len(objType.Fields) != 2 {
+			t.Fatalf("expected %s to have 2 fields, got %#v", typeName, objType)
+		}
+		if objType.Fields.ForName(fieldA).Description != "field a" {
+			t.Fatalf("field %s kept the type description instead of its own", fieldA)
+		}
+
+		inputType := parsed.Types[typeName+"Input"]
+		if inputType == nil || len(inputType.Fields) != 1 {
+			t.Fatalf("expected %sInput to have 1 field, got %#v", typeName, inputType)
+		}
+
+		unionType := parsed.Types[typeName+"Union"]
+		if unionType == nil || len(unionType.Types) != memberCount {
+			t.Fatalf("expected %sUnion to have %d members, got %#v", typeName, memberCount, unionType)
+		}
+	})
+}
+
+func sanitizeName(s, fallback string) string {
+	out := make([]rune, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+			out = append(out, r)
+		case r >= '0' && r <= '9', r == '_':
+			out = append(out, r)
+		}
+	}
+	if len(out) == 0 || (out[0] >= '0' && out[0] <= '9') {
+		return fallback
+	}
+	if len(out) >= 2 && out[0] == '_' && out[1] == '_' {
+		return fallback
+	}
+	return string(out)
+}
@@ -0,0 +1,155 @@
+// Package gqlfetch fetches a GraphQL schema via introspection and renders
+// it back to SDL. It is used both by the gqlfetch CLI and as a library for
+// embedding schema fetching in test suites and code generators.
+package gqlfetch
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+//go:embed introspectionQuery.graphql
+var introspectionQuery string
+
+// config holds the resolved set of Options for a FetchSchema call.
+type config struct {
+	transport   Transport
+	httpClient  *http.Client
+	headers     http.Header
+	timeout     time.Duration
+	middlewares []Middleware
+	httpMethod  string
+}
+
+// Option configures a FetchSchema call.
+type Option func(*config)
+
+// WithHTTPClient sets the http.Client used by the default HTTP transport.
+// It has no effect if WithTransport is also given.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *config) {
+		c.httpClient = client
+	}
+}
+
+// WithHeader adds a header value to send with the introspection request.
+// It may be called multiple times, including with the same key, to send
+// multi-value headers.
+func WithHeader(key, value string) Option {
+	return func(c *config) {
+		if c.headers == nil {
+			c.headers = http.Header{}
+		}
+		c.headers.Add(key, value)
+	}
+}
+
+// WithHeaders merges the given headers into the request headers.
+func WithHeaders(headers http.Header) Option {
+	return func(c *config) {
+		if c.headers == nil {
+			c.headers = http.Header{}
+		}
+		for key, values := range headers {
+			for _, value := range values {
+				c.headers.Add(key, value)
+			}
+		}
+	}
+}
+
+// WithTimeout bounds the time allowed to fetch the schema. It has no effect
+// if WithTransport is also given.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *config) {
+		c.timeout = timeout
+	}
+}
+
+// WithTransport overrides how the introspection request is sent, e.g. to
+// target an in-process handler or a non-HTTP protocol.
+func WithTransport(transport Transport) Option {
+	return func(c *config) {
+		c.transport = transport
+	}
+}
+
+// WithMiddleware wraps the Transport with the given Middleware, applied in
+// the order given (the first Middleware is outermost).
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *config) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// WithHTTPMethod selects the HTTP method used by the default HTTP transport:
+// http.MethodPost (the default) or http.MethodGet. It has no effect if
+// WithTransport is also given.
+func WithHTTPMethod(method string) Option {
+	return func(c *config) {
+		c.httpMethod = method
+	}
+}
+
+func resolveConfig(opts ...Option) *config {
+	cfg := &config{
+		timeout: 2 * time.Minute,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// clientWithTimeout returns the *http.Client to use for cfg, with
+// cfg.timeout applied. A caller-supplied client (via WithHTTPClient) is
+// never mutated in place: it is shallow-copied before the timeout is set,
+// so the caller keeps full ownership of the client it passed in.
+func (c *config) clientWithTimeout() *http.Client {
+	if c.httpClient == nil {
+		return &http.Client{Timeout: c.timeout}
+	}
+	client := *c.httpClient
+	client.Timeout = c.timeout
+	return &client
+}
+
+// FetchSchema runs the introspection query against endpoint and returns the
+// resulting Schema. By default it POSTs to endpoint over HTTP; use
+// WithTransport to fetch from a different source.
+func FetchSchema(ctx context.Context, endpoint string, opts ...Option) (*Schema, error) {
+	cfg := resolveConfig(opts...)
+
+	transport := cfg.transport
+	if transport == nil {
+		transport = &HTTPTransport{
+			Endpoint:   endpoint,
+			Client:     cfg.clientWithTimeout(),
+			Headers:    cfg.headers,
+			HTTPMethod: cfg.httpMethod,
+		}
+	}
+	for i := len(cfg.middlewares) - 1; i >= 0; i-- {
+		transport = cfg.middlewares[i](transport)
+	}
+
+	body, err := transport.Do(ctx, graphQLRequest{Query: introspectionQuery})
+	if err != nil {
+		return nil, fmt.Errorf("fetching schema: %w", err)
+	}
+
+	var schemaResponse introspectionRes
+	if err := json.Unmarshal(body, &schemaResponse); err != nil {
+		return nil, fmt.Errorf("decoding schema response: %w", err)
+	}
+
+	if len(schemaResponse.Errors) != 0 {
+		return nil, fmt.Errorf("introspection errors: %v", schemaResponse.Errors)
+	}
+
+	return &schemaResponse.Data.Schema, nil
+}
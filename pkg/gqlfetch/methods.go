@@ -0,0 +1,65 @@
+package gqlfetch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Method identifies a strategy for obtaining a schema from a server.
+type Method string
+
+const (
+	// MethodIntrospection runs the standard __schema introspection query.
+	MethodIntrospection Method = "introspection"
+	// MethodAPQ runs introspection using Apollo persisted queries, for
+	// servers that reject ad-hoc query bodies.
+	MethodAPQ Method = "apq"
+	// MethodSDL downloads the schema as raw SDL from a fixed path, for
+	// servers that disable introspection entirely.
+	MethodSDL Method = "sdl"
+)
+
+// FetchSchemaWithFallback tries each Method against endpoint in order,
+// returning the schema and Method produced by the first one that succeeds.
+// sdlPath is appended to endpoint for MethodSDL and is ignored otherwise.
+func FetchSchemaWithFallback(ctx context.Context, endpoint, sdlPath string, methods []Method, opts ...Option) (*ast.Schema, Method, error) {
+	var errs []string
+
+	for _, method := range methods {
+		schema, err := fetchWithMethod(ctx, endpoint, sdlPath, method, opts...)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", method, err))
+			continue
+		}
+		return schema, method, nil
+	}
+
+	return nil, "", fmt.Errorf("all methods failed:\n%s", strings.Join(errs, "\n"))
+}
+
+func fetchWithMethod(ctx context.Context, endpoint, sdlPath string, method Method, opts ...Option) (*ast.Schema, error) {
+	switch method {
+	case MethodIntrospection:
+		schema, err := FetchSchema(ctx, endpoint, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return ToAST(schema)
+
+	case MethodAPQ:
+		schema, err := FetchSchema(ctx, endpoint, append(opts, WithMiddleware(APQ()))...)
+		if err != nil {
+			return nil, err
+		}
+		return ToAST(schema)
+
+	case MethodSDL:
+		return FetchSDL(ctx, strings.TrimSuffix(endpoint, "/")+sdlPath, opts...)
+
+	default:
+		return nil, fmt.Errorf("unknown method %q", method)
+	}
+}
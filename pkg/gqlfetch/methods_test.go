@@ -0,0 +1,81 @@
+package gqlfetch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const introspectionJSON = `{
+	"data": {
+		"__schema": {
+			"queryType": {},
+			"mutationType": {},
+			"types": [
+				{
+					"kind": "OBJECT",
+					"name": "Query",
+					"fields": [
+						{"name": "hello", "description": "", "args": [], "type": {"kind": "SCALAR", "name": "String", "ofType": null}}
+					]
+				}
+			],
+			"directives": []
+		}
+	}
+}`
+
+func TestFetchSchemaWithFallbackIntrospection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(introspectionJSON))
+	}))
+	defer server.Close()
+
+	schema, method, err := FetchSchemaWithFallback(context.Background(), server.URL, "/schema.graphql", []Method{MethodIntrospection})
+	if err != nil {
+		t.Fatalf("FetchSchemaWithFallback: %v", err)
+	}
+	if method != MethodIntrospection {
+		t.Fatalf("expected method %q, got %q", MethodIntrospection, method)
+	}
+	if query := schema.Types["Query"]; query == nil || query.Fields.ForName("hello") == nil {
+		t.Fatalf("expected Query.hello in fetched schema, got %#v", schema.Types["Query"])
+	}
+}
+
+func TestFetchSchemaWithFallbackFallsBackToSDL(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	mux.HandleFunc("/graphql/schema.graphql", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("type Query {\n\thello: String\n}\n"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	schema, method, err := FetchSchemaWithFallback(context.Background(), server.URL+"/graphql", "/schema.graphql", []Method{MethodIntrospection, MethodSDL})
+	if err != nil {
+		t.Fatalf("FetchSchemaWithFallback: %v", err)
+	}
+	if method != MethodSDL {
+		t.Fatalf("expected fallback to method %q, got %q", MethodSDL, method)
+	}
+	if query := schema.Types["Query"]; query == nil || query.Fields.ForName("hello") == nil {
+		t.Fatalf("expected Query.hello in fetched schema, got %#v", schema.Types["Query"])
+	}
+}
+
+func TestFetchSchemaWithFallbackAllMethodsFail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, _, err := FetchSchemaWithFallback(context.Background(), server.URL, "/schema.graphql", []Method{MethodIntrospection, MethodSDL})
+	if err == nil {
+		t.Fatal("expected an error when every method fails")
+	}
+}
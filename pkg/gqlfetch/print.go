@@ -0,0 +1,194 @@
+package gqlfetch
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// PrintSchema renders a fetched Schema back to SDL.
+func PrintSchema(schema *Schema) string {
+	sb := &tabbedStringBuilder{
+		sb: &strings.Builder{},
+	}
+
+	printDirectives(sb, schema.Directives)
+	sb.WriteString("\n")
+	printTypes(sb, schema.Types)
+
+	return sb.String()
+}
+
+type tabbedStringBuilder struct {
+	sb          *strings.Builder
+	IndentLevel int
+}
+
+func (sb *tabbedStringBuilder) WriteString(s string) {
+	if sb.IndentLevel != 0 {
+		sb.sb.WriteString(strings.Repeat("\t", sb.IndentLevel))
+	}
+	sb.sb.WriteString(s)
+}
+
+func (sb *tabbedStringBuilder) String() string {
+	return sb.sb.String()
+}
+
+func printDirectives(sb *tabbedStringBuilder, directives []Directive) {
+	for _, directive := range directives {
+		printDescription(sb, directive.Description)
+		sb.WriteString(fmt.Sprintf("directive @%s", directive.Name))
+		if len(directive.Args) > 0 {
+			sb.WriteString("(\n")
+			sb.IndentLevel += 1
+			for _, arg := range directive.Args {
+				printDescription(sb, arg.Description)
+				sb.WriteString(fmt.Sprintf("%s: %s%s\n", arg.Name, arg.Type.String(), printDefaultValue(arg.DefaultValue)))
+			}
+			sb.IndentLevel -= 1
+			sb.WriteString(")")
+		}
+
+		if directive.IsRepeatable {
+			sb.WriteString(" repeatable")
+		}
+		sb.WriteString(" on ")
+		for i, location := range directive.Locations {
+			sb.WriteString(string(location))
+			if i < len(directive.Locations)-1 {
+				sb.WriteString(" | ")
+			}
+		}
+		sb.WriteString("\n")
+		sb.WriteString("\n")
+	}
+}
+
+func printDescription(sb *tabbedStringBuilder, description string) {
+	if description != "" {
+		sb.WriteString(fmt.Sprintf(`"""%s"""`, description))
+		sb.WriteString("\n")
+	}
+}
+
+// printDefaultValue renders a field or argument's default value. The
+// introspection defaultValue is already the value's SDL literal (e.g.
+// "10", `"foo"`, RED, [1, 2]), so it only needs the leading " = ".
+func printDefaultValue(defaultValue *string) string {
+	if defaultValue == nil {
+		return ""
+	}
+	return fmt.Sprintf(" = %s", *defaultValue)
+}
+
+// printDeprecated renders the @deprecated directive for a field or enum
+// value, if it is marked deprecated.
+func printDeprecated(isDeprecated bool, reason *string) string {
+	if !isDeprecated {
+		return ""
+	}
+	if reason == nil || *reason == "" {
+		return " @deprecated"
+	}
+	return fmt.Sprintf(" @deprecated(reason: %q)", *reason)
+}
+
+// printFieldArgs renders an object or interface field's argument list, if
+// it has any.
+func printFieldArgs(args []FieldArg) string {
+	if len(args) == 0 {
+		return ""
+	}
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		parts[i] = fmt.Sprintf("%s: %s%s", arg.Name, arg.Type.String(), printDefaultValue(arg.DefaultValue))
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func printTypes(sb *tabbedStringBuilder, types []Types) {
+	for _, typ := range types {
+		printDescription(sb, typ.Description)
+
+		switch typ.Kind {
+
+		case ast.Object:
+			sb.WriteString(fmt.Sprintf("type %s ", typ.Name))
+			if len(typ.Interfaces) > 0 {
+				sb.WriteString("implements ")
+				for i, intface := range typ.Interfaces {
+					sb.WriteString(intface.Name)
+					if i < len(typ.Interfaces)-1 {
+						sb.WriteString(" & ")
+					}
+				}
+			}
+			sb.WriteString("{\n")
+			sb.IndentLevel += 1
+			for _, field := range typ.Fields {
+				printDescription(sb, field.Description)
+				sb.WriteString(fmt.Sprintf("%s%s: %s%s\n", field.Name, printFieldArgs(field.Args), field.Type.String(), printDeprecated(field.IsDeprecated, field.DeprecationReason)))
+			}
+			sb.IndentLevel -= 1
+			sb.WriteString("}")
+
+		case ast.Union:
+			sb.WriteString(fmt.Sprintf("union %s = ", typ.Name))
+			var possible []*Type
+			if err := json.Unmarshal(typ.PossibleTypes, &possible); err != nil {
+				panic(err)
+			}
+			for i, typ := range possible {
+				sb.WriteString(typ.String())
+				if i < len(possible)-1 {
+					sb.WriteString(" | ")
+				}
+			}
+
+		case ast.Enum:
+			sb.WriteString(fmt.Sprintf("enum %s {\n", typ.Name))
+			var enumValues []EnumValue
+			if err := json.Unmarshal(typ.EnumValues, &enumValues); err != nil {
+				panic(err)
+			}
+			sb.IndentLevel += 1
+			for _, value := range enumValues {
+				printDescription(sb, value.Description)
+				sb.WriteString(fmt.Sprintf("%s%s\n", value.Name, printDeprecated(value.IsDeprecated, value.DeprecationReason)))
+			}
+			sb.IndentLevel -= 1
+			sb.WriteString("}")
+
+		case ast.Scalar:
+			sb.WriteString(fmt.Sprintf("scalar %s", typ.Name))
+
+		case ast.InputObject:
+			sb.WriteString(fmt.Sprintf("input %s {\n", typ.Name))
+			sb.IndentLevel += 1
+			for _, field := range typ.InputFields {
+				printDescription(sb, field.Description)
+				sb.WriteString(fmt.Sprintf("%s: %s%s\n", field.Name, field.Type.String(), printDefaultValue(field.DefaultValue)))
+			}
+			sb.IndentLevel -= 1
+			sb.WriteString("}")
+
+		case ast.Interface:
+			sb.WriteString(fmt.Sprintf("interface %s {\n", typ.Name))
+			sb.IndentLevel += 1
+			for _, field := range typ.Fields {
+				printDescription(sb, field.Description)
+				sb.WriteString(fmt.Sprintf("%s%s: %s%s\n", field.Name, printFieldArgs(field.Args), field.Type.String(), printDeprecated(field.IsDeprecated, field.DeprecationReason)))
+			}
+			sb.IndentLevel -= 1
+			sb.WriteString("}")
+
+		default:
+			panic(fmt.Sprint("not handling", typ.Kind))
+		}
+		sb.WriteString("\n")
+		sb.WriteString("\n")
+	}
+}
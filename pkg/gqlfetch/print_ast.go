@@ -0,0 +1,161 @@
+package gqlfetch
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// PrintASTSchema renders a parsed *ast.Schema back to SDL. It is used for
+// schemas obtained via a method that already produces an ast.Schema (SDL
+// download, federation composition, ...) rather than a raw introspection
+// response; use PrintSchema for the latter.
+func PrintASTSchema(schema *ast.Schema) string {
+	sb := &tabbedStringBuilder{sb: &strings.Builder{}}
+
+	directiveNames := make([]string, 0, len(schema.Directives))
+	for name := range schema.Directives {
+		directiveNames = append(directiveNames, name)
+	}
+	sort.Strings(directiveNames)
+	for _, name := range directiveNames {
+		printASTDirectiveDefinition(sb, schema.Directives[name])
+	}
+	sb.WriteString("\n")
+
+	typeNames := make([]string, 0, len(schema.Types))
+	for name := range schema.Types {
+		if !isBuiltinType(name) {
+			typeNames = append(typeNames, name)
+		}
+	}
+	sort.Strings(typeNames)
+	for _, name := range typeNames {
+		printASTType(sb, schema.Types[name])
+	}
+
+	return sb.String()
+}
+
+func printASTDirectiveDefinition(sb *tabbedStringBuilder, directive *ast.DirectiveDefinition) {
+	printDescription(sb, directive.Description)
+	sb.WriteString(fmt.Sprintf("directive @%s", directive.Name))
+	if len(directive.Arguments) > 0 {
+		sb.WriteString("(\n")
+		sb.IndentLevel += 1
+		for _, arg := range directive.Arguments {
+			printDescription(sb, arg.Description)
+			sb.WriteString(fmt.Sprintf("%s: %s%s\n", arg.Name, arg.Type.String(), printASTDefaultValue(arg.DefaultValue)))
+		}
+		sb.IndentLevel -= 1
+		sb.WriteString(")")
+	}
+	if directive.IsRepeatable {
+		sb.WriteString(" repeatable")
+	}
+	sb.WriteString(" on ")
+	for i, location := range directive.Locations {
+		sb.WriteString(string(location))
+		if i < len(directive.Locations)-1 {
+			sb.WriteString(" | ")
+		}
+	}
+	sb.WriteString("\n\n")
+}
+
+func printASTType(sb *tabbedStringBuilder, typ *ast.Definition) {
+	printDescription(sb, typ.Description)
+
+	switch typ.Kind {
+	case ast.Object, ast.Interface:
+		keyword := "type"
+		if typ.Kind == ast.Interface {
+			keyword = "interface"
+		}
+		sb.WriteString(fmt.Sprintf("%s %s ", keyword, typ.Name))
+		if len(typ.Interfaces) > 0 {
+			sb.WriteString("implements ")
+			for i, name := range typ.Interfaces {
+				sb.WriteString(name)
+				if i < len(typ.Interfaces)-1 {
+					sb.WriteString(" & ")
+				}
+			}
+			sb.WriteString(" ")
+		}
+		sb.WriteString("{\n")
+		sb.IndentLevel += 1
+		for _, field := range typ.Fields {
+			// gqlparser adds __schema/__type to the query root; they are
+			// reserved names and must not appear in SDL.
+			if strings.HasPrefix(field.Name, "__") {
+				continue
+			}
+			printDescription(sb, field.Description)
+			sb.WriteString(fmt.Sprintf("%s%s: %s%s\n", field.Name, printASTArgs(field.Arguments), field.Type.String(), printASTDeprecated(field.Directives)))
+		}
+		sb.IndentLevel -= 1
+		sb.WriteString("}")
+
+	case ast.Union:
+		sb.WriteString(fmt.Sprintf("union %s = %s", typ.Name, strings.Join(typ.Types, " | ")))
+
+	case ast.Enum:
+		sb.WriteString(fmt.Sprintf("enum %s {\n", typ.Name))
+		sb.IndentLevel += 1
+		for _, value := range typ.EnumValues {
+			printDescription(sb, value.Description)
+			sb.WriteString(fmt.Sprintf("%s%s\n", value.Name, printASTDeprecated(value.Directives)))
+		}
+		sb.IndentLevel -= 1
+		sb.WriteString("}")
+
+	case ast.Scalar:
+		sb.WriteString(fmt.Sprintf("scalar %s", typ.Name))
+
+	case ast.InputObject:
+		sb.WriteString(fmt.Sprintf("input %s {\n", typ.Name))
+		sb.IndentLevel += 1
+		for _, field := range typ.Fields {
+			printDescription(sb, field.Description)
+			sb.WriteString(fmt.Sprintf("%s: %s%s\n", field.Name, field.Type.String(), printASTDefaultValue(field.DefaultValue)))
+		}
+		sb.IndentLevel -= 1
+		sb.WriteString("}")
+
+	default:
+		panic(fmt.Sprint("not handling", typ.Kind))
+	}
+	sb.WriteString("\n\n")
+}
+
+func printASTArgs(args ast.ArgumentDefinitionList) string {
+	if len(args) == 0 {
+		return ""
+	}
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		parts[i] = fmt.Sprintf("%s: %s%s", arg.Name, arg.Type.String(), printASTDefaultValue(arg.DefaultValue))
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func printASTDefaultValue(v *ast.Value) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf(" = %s", v.String())
+}
+
+func printASTDeprecated(directives ast.DirectiveList) string {
+	directive := directives.ForName("deprecated")
+	if directive == nil {
+		return ""
+	}
+	if reason := directive.Arguments.ForName("reason"); reason != nil && reason.Value != nil {
+		return fmt.Sprintf(" @deprecated(reason: %s)", reason.Value.String())
+	}
+	return " @deprecated"
+}
@@ -0,0 +1,69 @@
+package gqlfetch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// TestPrintASTSchemaRoundTrip drives the path the CLI actually uses for its
+// default fetch command: FetchSchemaWithFallback (introspection) -> ToAST ->
+// PrintASTSchema, then reparses the result. This is distinct from
+// TestRoundTrip, which only exercises PrintSchema, the introspection-struct
+// printer ToAST also happens to call internally but that the CLI itself
+// never prints.
+func TestPrintASTSchemaRoundTrip(t *testing.T) {
+	// The only type is a non-root object named "Widget" rather than "Query", so
+	// the schema has no query root and this test stays focused on directive
+	// round-tripping.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"data": {
+				"__schema": {
+					"queryType": {},
+					"mutationType": {},
+					"types": [
+						{
+							"kind": "OBJECT",
+							"name": "Widget",
+							"fields": [
+								{"name": "hello", "description": "", "args": [], "type": {"kind": "SCALAR", "name": "String", "ofType": null}}
+							]
+						}
+					],
+					"directives": [
+						{
+							"name": "cached",
+							"description": "",
+							"locations": ["FIELD_DEFINITION"],
+							"isRepeatable": true,
+							"args": []
+						}
+					]
+				}
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	introspected, _, err := FetchSchemaWithFallback(context.Background(), server.URL, "/schema.graphql", []Method{MethodIntrospection})
+	if err != nil {
+		t.Fatalf("FetchSchemaWithFallback: %v", err)
+	}
+
+	sdl := PrintASTSchema(introspected)
+	parsed, err := gqlparser.LoadSchema(&ast.Source{Name: "roundtrip.graphql", Input: sdl})
+	if err != nil {
+		t.Fatalf("printed schema does not re-parse: %v\n%s", err, sdl)
+	}
+
+	cached := parsed.Directives["cached"]
+	if cached == nil || !cached.IsRepeatable {
+		t.Fatalf("expected @cached to round trip as repeatable, got: %#v\n%s", cached, sdl)
+	}
+}
@@ -0,0 +1,110 @@
+package gqlfetch
+
+import (
+	"os"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func strPtr(s string) *string { return &s }
+
+func testSchema(repeatable bool) *Schema {
+	return &Schema{
+		Directives: []Directive{
+			{
+				Name:         "auth",
+				IsRepeatable: repeatable,
+				Locations:    []ast.DirectiveLocation{ast.LocationFieldDefinition},
+				Args: []struct {
+					Name         string  `json:"name"`
+					Description  string  `json:"description"`
+					Type         *Type   `json:"type"`
+					DefaultValue *string `json:"defaultValue"`
+				}{
+					{Name: "role", Type: &Type{ast.Type{NamedType: "String"}}, DefaultValue: strPtr(`"USER"`)},
+				},
+			},
+		},
+		Types: []Types{
+			{
+				Kind: ast.InputObject,
+				Name: "Filter",
+				InputFields: []InputField{
+					{Name: "name", Type: Type{ast.Type{NamedType: "String"}}, DefaultValue: strPtr(`"default"`)},
+					{Name: "limit", Type: Type{ast.Type{NamedType: "Int"}}, DefaultValue: strPtr("10")},
+				},
+			},
+			{
+				Kind:       ast.Enum,
+				Name:       "Status",
+				EnumValues: []byte(`[{"name":"ACTIVE"},{"name":"RETIRED","isDeprecated":true,"deprecationReason":"no longer supported"}]`),
+			},
+			{
+				Kind: ast.Object,
+				Name: "Query",
+				Fields: []struct {
+					Name              string     `json:"name"`
+					Description       string     `json:"description"`
+					Args              []FieldArg `json:"args"`
+					Type              *Type      `json:"type"`
+					IsDeprecated      bool       `json:"isDeprecated"`
+					DeprecationReason *string    `json:"deprecationReason"`
+				}{
+					{Name: "status", Type: &Type{ast.Type{NamedType: "Status"}}},
+					{Name: "legacyStatus", Type: &Type{ast.Type{NamedType: "Status"}}, IsDeprecated: true, DeprecationReason: strPtr("use status")},
+				},
+			},
+		},
+	}
+}
+
+// TestPrintSchemaGolden checks PrintSchema against a golden file covering
+// default values, deprecation markers and a repeatable directive.
+func TestPrintSchemaGolden(t *testing.T) {
+	golden, err := os.ReadFile("testdata/roundtrip.graphql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := PrintSchema(testSchema(true))
+	if got != string(golden) {
+		t.Fatalf("printed schema does not match golden file:\n--- got ---\n%s\n--- want ---\n%s", got, golden)
+	}
+}
+
+// TestRoundTrip prints a schema and re-parses it with gqlparser, checking
+// that defaults, deprecation markers and the repeatable directive all
+// survive the round trip.
+func TestRoundTrip(t *testing.T) {
+	sdl := PrintSchema(testSchema(true))
+
+	parsed, err := gqlparser.LoadSchema(&ast.Source{Name: "roundtrip.graphql", Input: sdl})
+	if err != nil {
+		t.Fatalf("printed schema does not re-parse: %v", err)
+	}
+
+	filter := parsed.Types["Filter"]
+	if filter == nil {
+		t.Fatal("Filter type missing after round trip")
+	}
+	limit := filter.Fields.ForName("limit")
+	if limit == nil || limit.DefaultValue == nil || limit.DefaultValue.Raw != "10" {
+		t.Fatalf("Filter.limit default value did not round trip: %#v", limit)
+	}
+
+	status := parsed.Types["Status"]
+	if status == nil {
+		t.Fatal("Status type missing after round trip")
+	}
+	retired := status.EnumValues.ForName("RETIRED")
+	if retired == nil || retired.Directives.ForName("deprecated") == nil {
+		t.Fatalf("Status.RETIRED deprecation did not round trip: %#v", retired)
+	}
+
+	auth := parsed.Directives["auth"]
+	if auth == nil || !auth.IsRepeatable {
+		t.Fatalf("auth directive did not round trip as repeatable: %#v", auth)
+	}
+}
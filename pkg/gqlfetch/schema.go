@@ -0,0 +1,145 @@
+package gqlfetch
+
+import (
+	"encoding/json"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Schema is the result of an introspection query, shaped closely after the
+// GraphQL introspection response so that PrintSchema can render it back to
+// SDL without losing information the wire format carries (e.g. deprecation
+// and raw possible-types/enum-values payloads).
+type Schema struct {
+	QueryType    ast.Definition `json:"queryType"`
+	MutationType ast.Definition `json:"mutationType"`
+	Types        []Types        `json:"types"`
+	Directives   []Directive    `json:"directives"`
+}
+
+type Types struct {
+	Kind        ast.DefinitionKind `json:"kind"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Fields      []struct {
+		Name              string     `json:"name"`
+		Description       string     `json:"description"`
+		Args              []FieldArg `json:"args"`
+		Type              *Type      `json:"type"`
+		IsDeprecated      bool       `json:"isDeprecated"`
+		DeprecationReason *string    `json:"deprecationReason"`
+	} `json:"fields"`
+	InputFields   []InputField     `json:"inputFields"`
+	Interfaces    []ast.Definition `json:"interfaces"`
+	EnumValues    json.RawMessage  `json:"enumValues"`
+	PossibleTypes json.RawMessage  `json:"possibleTypes"`
+}
+
+type InputField struct {
+	Name         string  `json:"name"`
+	Description  string  `json:"description"`
+	Type         Type    `json:"type"`
+	DefaultValue *string `json:"defaultValue"`
+}
+
+// FieldArg mirrors the introspection __InputValue type used for arguments
+// on object and interface fields.
+type FieldArg struct {
+	Name         string  `json:"name"`
+	Description  string  `json:"description"`
+	Type         *Type   `json:"type"`
+	DefaultValue *string `json:"defaultValue"`
+}
+
+type Directive struct {
+	Name         string                  `json:"name"`
+	Description  string                  `json:"description"`
+	Locations    []ast.DirectiveLocation `json:"locations"`
+	IsRepeatable bool                    `json:"isRepeatable"`
+	Args         []struct {
+		Name         string  `json:"name"`
+		Description  string  `json:"description"`
+		Type         *Type   `json:"type"`
+		DefaultValue *string `json:"defaultValue"`
+	} `json:"args"`
+}
+
+// EnumValue mirrors the introspection __EnumValue type. It is decoded
+// separately from Types.EnumValues (a json.RawMessage) because
+// ast.EnumValueDefinition has no fields for isDeprecated/deprecationReason.
+type EnumValue struct {
+	Name              string  `json:"name"`
+	Description       string  `json:"description"`
+	IsDeprecated      bool    `json:"isDeprecated"`
+	DeprecationReason *string `json:"deprecationReason"`
+}
+
+type Type struct {
+	ast.Type
+}
+
+func (t *Type) UnmarshalJSON(data []byte) error {
+	var typ introspectedType
+	if err := json.Unmarshal(data, &typ); err != nil {
+		return err
+	}
+
+	head := introspectionTypeToAstType(&typ)
+	t.NamedType = head.NamedType
+	t.Elem = head.Elem
+	t.NonNull = head.NonNull
+
+	return nil
+}
+
+func introspectionTypeToAstType(typ *introspectedType) *ast.Type {
+	var res ast.Type
+	if typ.OfType == nil {
+		res.NamedType = *typ.Name
+		return &res
+	}
+
+	switch typ.Kind {
+	case NON_NULL:
+		res.NonNull = true
+		res.Elem = introspectionTypeToAstType(typ.OfType)
+		return &res
+	case LIST:
+		res.Elem = introspectionTypeToAstType(typ.OfType)
+		return &res
+	}
+
+	return nil
+}
+
+type introspectedType struct {
+	Kind   TypeKind          `json:"kind"`
+	Name   *string           `json:"name"`
+	OfType *introspectedType `json:"ofType"`
+}
+
+type TypeKind string
+
+const (
+	NON_NULL TypeKind = "NON_NULL"
+	LIST     TypeKind = "LIST"
+)
+
+type graphQLRequest struct {
+	Query      string                 `json:"query"`
+	Variables  map[string]interface{} `json:"variables,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+type graphqlErrs []graphqlErr
+
+type graphqlErr struct {
+	Message string `json:"message"`
+}
+
+type introspectionRes struct {
+	Errors graphqlErrs `json:"errors"`
+	Data   struct {
+		Schema Schema `json:"__schema"`
+	} `json:"data"`
+}
@@ -0,0 +1,48 @@
+package gqlfetch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// FetchSDL downloads raw SDL from url and parses it directly, for servers
+// that expose their schema as a static document instead of via
+// introspection. WithTransport and WithMiddleware have no effect here since
+// there is no GraphQL request to send.
+func FetchSDL(ctx context.Context, url string, opts ...Option) (*ast.Schema, error) {
+	cfg := resolveConfig(opts...)
+
+	client := cfg.clientWithTimeout()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("Accept", "application/graphql, text/plain, */*")
+	for key, values := range cfg.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching sdl: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching sdl: unexpected status %s", res.Status)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading sdl: %w", err)
+	}
+
+	return LoadSchemaSDL(string(body))
+}
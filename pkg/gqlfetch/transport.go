@@ -0,0 +1,121 @@
+package gqlfetch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Transport sends a raw GraphQL request and returns the raw response body.
+// The default Transport posts to an HTTP endpoint, but callers can supply
+// their own implementation to fetch schemas from in-process handlers
+// (e.g. gqlgen or graphql-go test servers) or non-HTTP protocols such as
+// subscriptions-transport-ws.
+type Transport interface {
+	Do(ctx context.Context, req graphQLRequest) ([]byte, error)
+}
+
+// Middleware wraps a Transport, letting callers observe or mutate requests
+// and responses (logging, retries, auth refresh, ...).
+type Middleware func(Transport) Transport
+
+// HTTPTransport is the default Transport, sending the request to Endpoint as
+// a POST with a JSON body, or as a GET with the request encoded in the query
+// string when HTTPMethod is http.MethodGet. GET is spec-compliant only for
+// query operations (never mutations), which matches every request this
+// package sends (introspection and federation's _service query).
+type HTTPTransport struct {
+	Endpoint string
+	Client   *http.Client
+	Headers  http.Header
+	// HTTPMethod selects GET or POST. It defaults to POST when empty.
+	HTTPMethod string
+}
+
+func (t *HTTPTransport) Do(ctx context.Context, gqlReq graphQLRequest) ([]byte, error) {
+	var req *http.Request
+	var err error
+	if t.HTTPMethod == http.MethodGet {
+		req, err = t.buildGetRequest(ctx, gqlReq)
+	} else {
+		req, err = t.buildPostRequest(ctx, gqlReq)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Negotiate the GraphQL-over-HTTP response media type per the spec,
+	// falling back to plain JSON for servers that predate it.
+	req.Header.Set("Accept", "application/graphql-response+json; charset=utf-8, application/json; charset=utf-8")
+	for key, values := range t.Headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	client := t.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sending request: %w", err)
+	}
+	defer res.Body.Close()
+
+	body := new(bytes.Buffer)
+	if _, err := body.ReadFrom(res.Body); err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+
+	return body.Bytes(), nil
+}
+
+func (t *HTTPTransport) buildPostRequest(ctx context.Context, gqlReq graphQLRequest) (*http.Request, error) {
+	buffer := new(bytes.Buffer)
+	if err := json.NewEncoder(buffer).Encode(gqlReq); err != nil {
+		return nil, fmt.Errorf("encoding request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.Endpoint, buffer)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+func (t *HTTPTransport) buildGetRequest(ctx context.Context, gqlReq graphQLRequest) (*http.Request, error) {
+	endpoint, err := url.Parse(t.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("parsing endpoint: %w", err)
+	}
+
+	query := endpoint.Query()
+	query.Set("query", gqlReq.Query)
+	if len(gqlReq.Variables) > 0 {
+		variables, err := json.Marshal(gqlReq.Variables)
+		if err != nil {
+			return nil, fmt.Errorf("encoding variables: %w", err)
+		}
+		query.Set("variables", string(variables))
+	}
+	if len(gqlReq.Extensions) > 0 {
+		extensions, err := json.Marshal(gqlReq.Extensions)
+		if err != nil {
+			return nil, fmt.Errorf("encoding extensions: %w", err)
+		}
+		query.Set("extensions", string(extensions))
+	}
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+	return req, nil
+}
@@ -0,0 +1,48 @@
+package gqlfetch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPTransportDoDefaultsToPost(t *testing.T) {
+	var gotMethod string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer server.Close()
+
+	transport := &HTTPTransport{Endpoint: server.URL}
+	if _, err := transport.Do(context.Background(), graphQLRequest{Query: "{ hello }"}); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if gotMethod != http.MethodPost {
+		t.Fatalf("expected POST, got %s", gotMethod)
+	}
+}
+
+func TestHTTPTransportDoGet(t *testing.T) {
+	var gotMethod, gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotQuery = r.URL.Query().Get("query")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer server.Close()
+
+	transport := &HTTPTransport{Endpoint: server.URL, HTTPMethod: http.MethodGet}
+	if _, err := transport.Do(context.Background(), graphQLRequest{Query: "{ hello }"}); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if gotMethod != http.MethodGet {
+		t.Fatalf("expected GET, got %s", gotMethod)
+	}
+	if gotQuery != "{ hello }" {
+		t.Fatalf("expected query in URL, got %q", gotQuery)
+	}
+}